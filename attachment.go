@@ -0,0 +1,156 @@
+package goemail
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"gopkg.in/gomail.v2"
+)
+
+// fileAttachment represents a single attached or embedded file, either
+// sourced from a file path (path) or streamed from an io.Reader (reader).
+// Exactly one of the two is set. A reader-based attachment's content is
+// cached after the first read (see readerBytes) so it survives being read
+// more than once, e.g. across a retried send.
+type fileAttachment struct {
+	name   string
+	path   string
+	reader io.Reader
+	cache  *readerCache
+
+	contentType string
+	contentID   string
+}
+
+// readerCache holds a reader-based attachment's content once it has been
+// read, so a retried send reuses it instead of reading the now-exhausted
+// io.Reader again.
+type readerCache struct {
+	once sync.Once
+	data []byte
+	err  error
+}
+
+// readerBytes returns f.reader's full content, reading it only once no
+// matter how many times it's called.
+func (f fileAttachment) readerBytes() ([]byte, error) {
+	f.cache.once.Do(func() {
+		f.cache.data, f.cache.err = io.ReadAll(f.reader)
+	})
+	return f.cache.data, f.cache.err
+}
+
+// attachOptions holds the settings collected from a set of AttachOption
+// values.
+type attachOptions struct {
+	contentType string
+	contentID   string
+}
+
+// AttachOption customizes a file passed to AttachReader or EmbedReader.
+type AttachOption func(*attachOptions)
+
+// WithContentType overrides the MIME type gomail would otherwise infer from
+// the file name extension.
+func WithContentType(contentType string) AttachOption {
+	return func(o *attachOptions) {
+		o.contentType = contentType
+	}
+}
+
+// WithContentID sets the Content-ID header used to reference the file from
+// an HTML body, e.g. <img src="cid:logo">. It is mainly useful for
+// AttachReader; EmbedReader sets it from its cid argument automatically.
+func WithContentID(cid string) AttachOption {
+	return func(o *attachOptions) {
+		o.contentID = cid
+	}
+}
+
+// newPathAttachment builds a fileAttachment for a file-path based attachment
+// or embed, as used by SetAttachements and SetImagesToEmbed.
+func newPathAttachment(path string) fileAttachment {
+	return fileAttachment{name: filepath.Base(path), path: path}
+}
+
+// newReaderAttachment builds a fileAttachment that streams from r, applying
+// any AttachOption values.
+func newReaderAttachment(name string, r io.Reader, opts []AttachOption) fileAttachment {
+	o := attachOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return fileAttachment{
+		name:        name,
+		reader:      r,
+		cache:       &readerCache{},
+		contentType: o.contentType,
+		contentID:   o.contentID,
+	}
+}
+
+// attach registers the file on a gomail message via addFn, which is either
+// (*gomail.Message).Attach or (*gomail.Message).Embed.
+func (f fileAttachment) attach(addFn func(name string, settings ...gomail.FileSetting)) {
+	headers := map[string][]string{}
+	if f.contentType != "" {
+		headers["Content-Type"] = []string{f.contentType + `; name="` + f.name + `"`}
+	}
+	if f.contentID != "" {
+		headers["Content-ID"] = []string{"<" + f.contentID + ">"}
+	}
+
+	var settings []gomail.FileSetting
+	if len(headers) > 0 {
+		settings = append(settings, gomail.SetHeader(headers))
+	}
+
+	if f.reader != nil {
+		settings = append(settings, gomail.SetCopyFunc(func(w io.Writer) error {
+			data, err := f.readerBytes()
+			if err != nil {
+				return err
+			}
+			_, err = w.Write(data)
+			return err
+		}))
+		addFn(f.name, settings...)
+		return
+	}
+
+	addFn(f.path, settings...)
+}
+
+// writeTo streams the attachment's content to w, opening f.path when the
+// attachment is file-based rather than reader-based. It is used by the HTTP
+// API senders, which carry attachments as form parts or base64 payloads
+// rather than through gomail's MIME writer.
+func (f fileAttachment) writeTo(w io.Writer) error {
+	if f.reader != nil {
+		data, err := f.readerBytes()
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	}
+	file, err := os.Open(f.path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = io.Copy(w, file)
+	return err
+}
+
+// bytes reads the attachment's content fully into memory, for HTTP API
+// senders that carry attachments as a base64-encoded blob rather than a
+// stream.
+func (f fileAttachment) bytes() ([]byte, error) {
+	if f.reader != nil {
+		return f.readerBytes()
+	}
+	return os.ReadFile(f.path)
+}
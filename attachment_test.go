@@ -0,0 +1,26 @@
+package goemail
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFileAttachmentReaderBytesCachesAcrossCalls(t *testing.T) {
+	f := newReaderAttachment("report.csv", strings.NewReader("a,b,c"), nil)
+
+	first, err := f.bytes()
+	if err != nil {
+		t.Fatalf("bytes returned error: %v", err)
+	}
+	if string(first) != "a,b,c" {
+		t.Fatalf("bytes = %q, want %q", first, "a,b,c")
+	}
+
+	second, err := f.bytes()
+	if err != nil {
+		t.Fatalf("second bytes call returned error: %v", err)
+	}
+	if string(second) != "a,b,c" {
+		t.Errorf("second bytes call = %q, want %q (reader should not be read twice)", second, "a,b,c")
+	}
+}
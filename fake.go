@@ -0,0 +1,38 @@
+package goemail
+
+import (
+	"context"
+	"io"
+)
+
+// FakeSender writes the composed MIME message to Writer instead of sending it
+// anywhere, mirroring the dev/fake emailer pattern used by identity servers
+// for local testing. Construct one with NewFakeSender and pass it to
+// NewWithSender.
+type FakeSender struct {
+	Writer io.Writer
+}
+
+// NewFakeSender creates an email instance that writes every composed message
+// to w rather than delivering it, for use in local development and tests.
+//
+// Parameters:
+//   - w: The writer that composed MIME messages are written to.
+//
+// Returns:
+//   - *email: A pointer to the initialized email instance.
+func NewFakeSender(w io.Writer) *email {
+	return NewWithSender(&FakeSender{Writer: w})
+}
+
+// Send validates e and writes the composed MIME message to f.Writer. There is
+// nothing to retry against, so retry is accepted for interface compatibility
+// and otherwise ignored.
+func (f *FakeSender) Send(ctx context.Context, e *email, retry int) error {
+	if err := e.validate(); err != nil {
+		return err
+	}
+	gm := getMessage(e)
+	_, err := gm.WriteTo(f.Writer)
+	return err
+}
@@ -0,0 +1,115 @@
+package goemail
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+)
+
+// TestFakeSenderAttachAndEmbedRoundTrip builds an email with an attachment
+// and an embedded image, sends it through FakeSender, and re-parses the
+// written MIME message to confirm the HTML body, its plain-text
+// alternative, the attachment, and the embedded image all survive
+// round-trip unchanged.
+func TestFakeSenderAttachAndEmbedRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	e := NewFakeSender(&buf)
+	e.SetSender("from@example.com")
+	e.SetTo([]string{"to@example.com"})
+	e.SetSubject("Hello")
+	if err := e.SetContent(Content{
+		Type:          ContentHTML,
+		Content:       "<p>Hi there</p>",
+		AutoPlainText: true,
+	}); err != nil {
+		t.Fatalf("SetContent returned error: %v", err)
+	}
+	e.AttachReader("report.txt", strings.NewReader("report body"))
+	e.EmbedReader("logo", strings.NewReader("logo bytes"), WithContentType("image/png"))
+
+	if err := e.Send(context.Background(), 1); err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+
+	msg, err := mail.ReadMessage(&buf)
+	if err != nil {
+		t.Fatalf("mail.ReadMessage: %v", err)
+	}
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatalf("ParseMediaType: %v", err)
+	}
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		t.Fatalf("Content-Type = %q, want a multipart type", mediaType)
+	}
+
+	var gotHTML, gotText, gotAttachment, gotEmbed string
+	var foundContentID string
+	walkParts(t, multipart.NewReader(msg.Body, params["boundary"]), func(p *multipart.Part) {
+		raw, err := io.ReadAll(p)
+		if err != nil {
+			t.Fatalf("reading part: %v", err)
+		}
+		data := raw
+		if p.Header.Get("Content-Transfer-Encoding") == "base64" {
+			decoded, err := base64.StdEncoding.DecodeString(string(raw))
+			if err != nil {
+				t.Fatalf("decoding base64 part: %v", err)
+			}
+			data = decoded
+		}
+		switch ct, _, _ := mime.ParseMediaType(p.Header.Get("Content-Type")); {
+		case ct == "text/html":
+			gotHTML = string(data)
+		case ct == "text/plain" && p.FileName() == "":
+			gotText = string(data)
+		case p.FileName() == "report.txt":
+			gotAttachment = string(data)
+		case ct == "image/png":
+			gotEmbed = string(data)
+			foundContentID = p.Header.Get("Content-Id")
+		}
+	})
+
+	if gotHTML != "<p>Hi there</p>" {
+		t.Errorf("html body = %q, want %q", gotHTML, "<p>Hi there</p>")
+	}
+	if gotText == "" {
+		t.Error("plain-text alternative did not survive round-trip")
+	}
+	if gotAttachment != "report body" {
+		t.Errorf("attachment content = %q, want %q", gotAttachment, "report body")
+	}
+	if gotEmbed != "logo bytes" {
+		t.Errorf("embedded image content = %q, want %q", gotEmbed, "logo bytes")
+	}
+	if !strings.Contains(foundContentID, "logo") {
+		t.Errorf("embedded image Content-Id = %q, want it to reference %q", foundContentID, "logo")
+	}
+}
+
+// walkParts recursively visits every leaf part of a (possibly nested)
+// multipart message, calling visit for each one that isn't itself multipart.
+func walkParts(t *testing.T, r *multipart.Reader, visit func(*multipart.Part)) {
+	t.Helper()
+	for {
+		p, err := r.NextPart()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			t.Fatalf("NextPart: %v", err)
+		}
+		if mediaType, params, err := mime.ParseMediaType(p.Header.Get("Content-Type")); err == nil && strings.HasPrefix(mediaType, "multipart/") {
+			walkParts(t, multipart.NewReader(p, params["boundary"]), visit)
+			continue
+		}
+		visit(p)
+	}
+}
@@ -2,18 +2,18 @@ package goemail
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/mail"
 
 	"gopkg.in/gomail.v2"
 )
 
 var dialer *gomail.Dialer
 
-// gomailSender represents a mail sender with a configurable retry mechanism.
-// The retry field specifies the number of times the sender will attempt to resend
-// an email in case of failure.
-type gomailSender struct {
-	retry int
-}
+// gomailSender is the default sender implementation. It delivers email over
+// SMTP using the dialer configured via New.
+type gomailSender struct{}
 
 // Send attempts to send an email using the gomailSender. It first validates the email
 // and then retries sending it using an exponential backoff strategy if necessary.
@@ -21,45 +21,113 @@ type gomailSender struct {
 // Parameters:
 //   - ctx: The context for managing request deadlines and cancellations.
 //   - e: A pointer to the email object to be sent.
+//   - retry: The number of retry attempts in case of failure.
 //
 // Returns:
 //   - An error if the email validation fails or if the email cannot be sent
 //     after the configured retries.
-func (g *gomailSender) Send(ctx context.Context, e *email) error {
+func (g *gomailSender) Send(ctx context.Context, e *email, retry int) error {
 	err := e.validate()
 	if err != nil {
 		return err
 	}
-	return exponentialBackOffRetry(ctx, e, sendEmail, g.retry)
+	return exponentialBackOffRetry(ctx, e, func(e *email) error {
+		return sendEmail(ctx, e)
+	}, DefaultRetryPolicy(retry))
 }
 
 // sendEmail sends an email using the provided email configuration.
 // It constructs the email message using the getMessage function and
-// sends it via the dialer associated with the email instance.
+// sends it via e's connection pool when configured, or a fresh dial
+// otherwise.
+//
+// It calls the SendCloser's Send method directly rather than going through
+// gomail.Send/Dialer.DialAndSend, both of which wrap whatever error the SMTP
+// client returns in a plain fmt.Errorf("gomail: could not send email %d: %v",
+// ...) — the %v (not %w) strips the original error type, so isRetryableSMTPError
+// and isConnectionError could never recognize a *textproto.Error or io.EOF
+// underneath it. Sending directly preserves that type.
 //
 // Parameters:
+//   - ctx: The context for managing request deadlines and cancellations,
+//     used to stop waiting for a pooled connection to free up.
 //   - e: A pointer to an email instance containing the email details
 //     and the dialer for sending the email.
 //
 // Returns:
 //   - error: An error if the email fails to send, or nil if the email
 //     is sent successfully.
-func sendEmail(e *email) error {
+func sendEmail(ctx context.Context, e *email) error {
 	gm := getMessage(e)
-	return e.dialer.DialAndSend(gm)
+	from, to, err := messageEnvelope(gm)
+	if err != nil {
+		return err
+	}
+
+	if e.pool != nil {
+		return e.pool.send(ctx, from, to, gm)
+	}
+
+	sc, err := e.dialer.Dial()
+	if err != nil {
+		return err
+	}
+	defer sc.Close()
+	return sc.Send(from, to, gm)
+}
+
+// messageEnvelope extracts the SMTP envelope sender and recipients from gm's
+// "Sender"/"From" and "To"/"Cc"/"Bcc" headers, the same way gomail.Send's
+// unexported getFrom/getRecipients do, so sendEmail and connPool.send can
+// call a SendCloser's Send method directly instead of going through
+// gomail.Send.
+func messageEnvelope(gm *gomail.Message) (from string, to []string, err error) {
+	fromHeader := gm.GetHeader("Sender")
+	if len(fromHeader) == 0 {
+		fromHeader = gm.GetHeader("From")
+		if len(fromHeader) == 0 {
+			return "", nil, errors.New(`goemail: invalid message, "From" field is absent`)
+		}
+	}
+	from, err = parseAddress(fromHeader[0])
+	if err != nil {
+		return "", nil, err
+	}
+
+	for _, field := range []string{"To", "Cc", "Bcc"} {
+		for _, a := range gm.GetHeader(field) {
+			addr, err := parseAddress(a)
+			if err != nil {
+				return "", nil, err
+			}
+			to = append(to, addr)
+		}
+	}
+	return from, to, nil
+}
+
+// parseAddress extracts the bare address from a "Name <address>" or plain
+// address header value.
+func parseAddress(field string) (string, error) {
+	addr, err := mail.ParseAddress(field)
+	if err != nil {
+		return "", fmt.Errorf("goemail: invalid address %q: %w", field, err)
+	}
+	return addr.Address, nil
 }
 
 // getMessage constructs and returns a *gomail.Message based on the provided email struct.
-// It sets the email body, headers, and optionally embeds images.
+// It sets the email body, headers, and optionally embeds images and attaches files.
 //
 // Parameters:
 //   - e: A pointer to an email struct containing the email details such as content, recipients, subject, etc.
 //
 // Behavior:
-//   - Sets the email body based on the content type (HTML or plain text).
+//   - Sets the email body based on the content type (HTML or plain text), adding
+//     a text/plain alternative part when e.plainText is set.
 //   - Configures the "To", "Cc", and "Bcc" headers if recipients are provided.
 //   - Sets the "Subject", "From", "X-SES-MESSAGE-TAGS", and "Return-Path" headers.
-//   - Embeds images into the email if any are specified.
+//   - Embeds images and attaches files into the email if any are specified.
 //
 // Returns:
 //   - A pointer to a gomail.Message object representing the constructed email.
@@ -67,7 +135,12 @@ func getMessage(e *email) *gomail.Message {
 	gm := gomail.NewMessage()
 
 	if e.contentType == ContentHTML {
-		gm.SetBody("text/html", e.content)
+		if e.plainText != "" {
+			gm.SetBody("text/plain", e.plainText)
+			gm.AddAlternative("text/html", e.content)
+		} else {
+			gm.SetBody("text/html", e.content)
+		}
 	} else if e.contentType == ContentText {
 		gm.SetBody("text/plain", e.content)
 	}
@@ -101,10 +174,13 @@ func getMessage(e *email) *gomail.Message {
 	gm.SetHeader("X-SES-MESSAGE-TAGS", e.tags)
 	gm.SetHeader("Return-Path", e.returnEmail)
 
-	if len(e.imagesToEmbed) > 0 {
-		for _, v := range e.imagesToEmbed {
-			gm.Embed(v)
-		}
+	for _, f := range e.imagesToEmbed {
+		f.attach(gm.Embed)
 	}
+
+	for _, f := range e.attachments {
+		f.attach(gm.Attach)
+	}
+
 	return gm
 }
@@ -3,14 +3,25 @@ package goemail
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"html/template"
 	"log"
-	"math"
 	"math/rand"
+	"net/textproto"
 	"time"
 )
 
+// formatAddress renders an email address together with its optional display
+// name in the conventional "Name <address>" form used across the HTTP API
+// backends. When name is empty the bare address is returned.
+func formatAddress(address, name string) string {
+	if name == "" {
+		return address
+	}
+	return fmt.Sprintf("%s <%s>", name, address)
+}
+
 // processTemplate parses the template file at the specified path and executes it
 // with the provided data, returning the resulting string or an error if any occurs.
 //
@@ -37,45 +48,130 @@ func processTemplate(templatePath string, data interface{}) (string, error) {
 	return buf.String(), nil
 }
 
-// exponentialBackOffRetry attempts to execute a given function with exponential backoff retries.
-// It retries the function up to the specified number of times or until the context is canceled.
+// RetryPolicy controls how exponentialBackOffRetry paces and filters
+// retries.
+type RetryPolicy struct {
+	// Base is the backoff for the first retry; each subsequent retry doubles
+	// it, capped at Cap.
+	Base time.Duration
+
+	// Cap bounds the backoff so it stops growing after enough retries.
+	Cap time.Duration
+
+	// MaxAttempts is the maximum number of calls to fn, including the first.
+	MaxAttempts int
+
+	// Classifier reports whether err is worth retrying. A nil Classifier
+	// retries every error. Returning false stops the retry loop immediately,
+	// even if attempts remain.
+	Classifier func(error) bool
+}
+
+// defaultRetryBase and defaultRetryCap are the backoff bounds used by
+// DefaultRetryPolicy.
+const (
+	defaultRetryBase = 2 * time.Second
+	defaultRetryCap  = 32 * time.Second
+)
+
+// DefaultRetryPolicy builds the policy exponentialBackOffRetry falls back to
+// when a sender is only given a retry count: up to maxAttempts tries with
+// capped exponential backoff, skipping retries for permanent SMTP 5xx
+// replies via isRetryableSMTPError.
+func DefaultRetryPolicy(maxAttempts int) RetryPolicy {
+	return RetryPolicy{
+		Base:        defaultRetryBase,
+		Cap:         defaultRetryCap,
+		MaxAttempts: maxAttempts,
+		Classifier:  isRetryableSMTPError,
+	}
+}
+
+// isRetryableSMTPError reports whether err is worth retrying. Permanent SMTP
+// 5xx replies (invalid recipient, auth failure, message rejected) are not;
+// 4xx replies and any other error (network failures, timeouts) are treated
+// as transient and retried. For the HTTP API senders, an httpStatusError is
+// classified the opposite way around: a 4xx (other than 429, a rate limit)
+// is a permanent client error, while a 5xx or 429 is worth retrying.
+func isRetryableSMTPError(err error) bool {
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) {
+		return protoErr.Code < 500
+	}
+
+	var httpErr *httpStatusError
+	if errors.As(err, &httpErr) {
+		if httpErr.status == 429 {
+			return true
+		}
+		return httpErr.status < 400 || httpErr.status >= 500
+	}
+
+	return true
+}
+
+// exponentialBackOffRetry attempts to execute fn, retrying on failure
+// according to policy until it succeeds, policy.Classifier rejects the
+// error as permanent, policy.MaxAttempts is reached, or ctx is done.
 //
 // Parameters:
 //   - ctx: The context to manage cancellation and timeout.
 //   - e: A pointer to an email object (custom type) passed to the function being retried.
 //   - fn: The function to be executed. It takes an *email as input and returns an error.
-//   - retry: The maximum number of retry attempts.
+//   - policy: The backoff bounds, attempt limit, and error classifier to apply.
 //
 // Behavior:
-//   - The function starts with an initial delay of 2 seconds and doubles the delay
-//     with each retry attempt (exponential backoff).
-//   - A random jitter is added to the delay to prevent synchronized retries in distributed systems.
+//   - Backoff follows min(policy.Cap, policy.Base*2^(i-1)) plus full jitter
+//     (a uniform random value in [0, backoff)), so attempts don't synchronize
+//     across callers.
+//   - If ctx has a deadline, the final sleep is shrunk to fit the remaining
+//     time instead of being abandoned as a timeout.
 //   - If the function succeeds (returns nil), the retry loop exits early.
-//   - If the context is canceled or times out, the function returns an error indicating a timeout.
-//   - If all retries are exhausted, the function returns an error indicating failure.
+//   - If policy.Classifier rejects the error, the loop stops and that error
+//     is returned without further retries.
 //
 // Returns:
-//   - nil if the function succeeds within the retry attempts.
-//   - An error if the retries are exhausted or the context is canceled.
-func exponentialBackOffRetry(ctx context.Context, e *email, fn func(e *email) error, retry int) error {
-	delay := 2 * time.Second
-	for i := 1; i <= retry; i++ {
-		err := fn(e)
-		if err == nil {
+//   - nil if the function succeeds within the allowed attempts.
+//   - The last error seen if retries are exhausted, classified as permanent,
+//     or ctx is done before the next attempt.
+func exponentialBackOffRetry(ctx context.Context, e *email, fn func(e *email) error, policy RetryPolicy) error {
+	var lastErr error
+	backoff := policy.Base
+
+	for i := 1; i <= policy.MaxAttempts; i++ {
+		lastErr = fn(e)
+		if lastErr == nil {
 			return nil
-		} else {
-			fmt.Println("err")
 		}
-		backoff := delay * time.Duration(math.Pow(2, float64(i)))
-		jitter := time.Duration(rand.Int63n(int64(i)))
-		sleep := backoff + jitter
+
+		if policy.Classifier != nil && !policy.Classifier(lastErr) {
+			return lastErr
+		}
+
+		if i == policy.MaxAttempts {
+			break
+		}
+
+		sleep := backoff + time.Duration(rand.Int63n(int64(backoff)))
+		if deadline, ok := ctx.Deadline(); ok {
+			if remaining := time.Until(deadline); remaining < sleep {
+				sleep = remaining
+			}
+		}
 
 		select {
 		case <-time.After(sleep):
 			fmt.Println("send email retry ", i)
 		case <-ctx.Done():
-			return fmt.Errorf("send email timeout")
+			return lastErr
+		}
+
+		if backoff < policy.Cap {
+			backoff *= 2
+			if backoff > policy.Cap {
+				backoff = policy.Cap
+			}
 		}
 	}
-	return fmt.Errorf("retries are exhausted")
+	return fmt.Errorf("retries are exhausted: %w", lastErr)
 }
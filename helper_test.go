@@ -0,0 +1,130 @@
+package goemail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/textproto"
+	"testing"
+	"time"
+)
+
+func TestIsRetryableSMTPError(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"permanent SMTP 5xx", &textproto.Error{Code: 550, Msg: "mailbox unavailable"}, false},
+		{"transient SMTP 4xx", &textproto.Error{Code: 421, Msg: "service not available"}, true},
+		{"permanent HTTP 4xx", &httpStatusError{provider: "mailgun", status: 401}, false},
+		{"HTTP 429 rate limit", &httpStatusError{provider: "sendgrid", status: 429}, true},
+		{"transient HTTP 5xx", &httpStatusError{provider: "ses", status: 503}, true},
+		{"unclassified error", errors.New("connection reset"), true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isRetryableSMTPError(tc.err); got != tc.want {
+				t.Errorf("isRetryableSMTPError(%v) = %v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestExponentialBackOffRetrySucceedsWithoutExhaustingAttempts(t *testing.T) {
+	attempts := 0
+	policy := RetryPolicy{Base: time.Millisecond, Cap: time.Millisecond, MaxAttempts: 5}
+
+	err := exponentialBackOffRetry(context.Background(), &email{}, func(e *email) error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("transient")
+		}
+		return nil
+	}, policy)
+
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+}
+
+func TestExponentialBackOffRetryStopsOnPermanentError(t *testing.T) {
+	attempts := 0
+	policy := RetryPolicy{
+		Base:        time.Millisecond,
+		Cap:         time.Millisecond,
+		MaxAttempts: 5,
+		Classifier:  isRetryableSMTPError,
+	}
+	permanent := &textproto.Error{Code: 550, Msg: "mailbox unavailable"}
+
+	err := exponentialBackOffRetry(context.Background(), &email{}, func(e *email) error {
+		attempts++
+		return permanent
+	}, policy)
+
+	if !errors.Is(err, permanent) {
+		t.Errorf("expected the permanent error back unwrapped, got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1 (no retries for a permanent error)", attempts)
+	}
+}
+
+func TestExponentialBackOffRetryExhaustsMaxAttempts(t *testing.T) {
+	attempts := 0
+	policy := RetryPolicy{Base: time.Millisecond, Cap: time.Millisecond, MaxAttempts: 3}
+
+	err := exponentialBackOffRetry(context.Background(), &email{}, func(e *email) error {
+		attempts++
+		return errors.New("always fails")
+	}, policy)
+
+	if err == nil {
+		t.Fatal("expected an error after exhausting retries")
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+}
+
+func TestExponentialBackOffRetryStopsWhenContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	attempts := 0
+	policy := RetryPolicy{Base: time.Second, Cap: time.Second, MaxAttempts: 5}
+
+	err := exponentialBackOffRetry(ctx, &email{}, func(e *email) error {
+		attempts++
+		return errors.New("transient")
+	}, policy)
+
+	if err == nil {
+		t.Fatal("expected an error when ctx is already done")
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1 (no sleep should be attempted once ctx is done)", attempts)
+	}
+}
+
+func TestFormatAddress(t *testing.T) {
+	cases := []struct {
+		address string
+		name    string
+		want    string
+	}{
+		{"a@example.com", "", "a@example.com"},
+		{"a@example.com", "Alice", fmt.Sprintf("%s <%s>", "Alice", "a@example.com")},
+	}
+
+	for _, tc := range cases {
+		if got := formatAddress(tc.address, tc.name); got != tc.want {
+			t.Errorf("formatAddress(%q, %q) = %q, want %q", tc.address, tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package goemail
+
+import "fmt"
+
+// httpStatusError wraps a non-2xx HTTP response from one of the HTTP API
+// senders (MailgunSender, SendGridSender, SESSender) so the retry
+// classifier can tell a permanent client error (4xx, other than a 429 rate
+// limit) from a transient one (5xx, 429, or a network failure) the same way
+// isRetryableSMTPError does for SMTP replies.
+type httpStatusError struct {
+	provider string
+	status   int
+	body     string
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("%s: %d: %s", e.provider, e.status, e.body)
+}
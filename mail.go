@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"os"
+	"time"
 
 	"gopkg.in/gomail.v2"
 )
@@ -21,6 +24,11 @@ type email struct {
 	// dialer is used to establish a connection to the SMTP server for sending emails.
 	dialer *gomail.Dialer
 
+	// pool, when non-nil, reuses SMTP connections across Send and SendBatch
+	// calls instead of dialing a fresh connection for each message. It is
+	// set by New when ConnectionDetails.Pool requests pooling.
+	pool *connPool
+
 	// content holds the body of the email.
 	content string
 
@@ -51,11 +59,24 @@ type email struct {
 	// bcc is a list of email addresses to be included in the BCC (blind carbon copy) field.
 	bcc []string
 
-	// imagesToEmbed is a list of file paths for images to be embedded in the email.
-	imagesToEmbed []string
+	// imagesToEmbed is a list of images to be embedded in the email, sourced
+	// from either a file path or an io.Reader.
+	imagesToEmbed []fileAttachment
+
+	// attachments is a list of files to be attached to the email, sourced
+	// from either a file path or an io.Reader.
+	attachments []fileAttachment
+
+	// plainText is the text/plain alternative sent alongside HTML content,
+	// either provided explicitly via Content.PlainText or derived from the
+	// HTML body when Content.AutoPlainText is set. Empty for plain-text
+	// emails, where content itself is already text/plain.
+	plainText string
 
-	// attachments is a list of file paths for images to be attached in the email.
-	attachments []string
+	// transport overrides the default SMTP delivery path with an alternate
+	// sender implementation. It is nil unless the email was created with
+	// NewWithSender, in which case Send routes through it instead of dialer.
+	transport sender
 }
 
 // MailDetails represents the details of an email to be sent.
@@ -92,15 +113,23 @@ type MailDetails struct {
 // an optional template path for rendering, and any associated data.
 //
 // Fields:
-// - Type: Specifies the type of the content (e.g., "text/plain", "text/html").
-// - Content: The actual content of the email as a string.
-// - TemplatePath: Path to the template file used for rendering the content (optional).
-// - Data: Data to be used for populating the template (if applicable).
+//   - Type: Specifies the type of the content (e.g., "text/plain", "text/html").
+//   - Content: The actual content of the email as a string.
+//   - TemplatePath: Path to the template file used for rendering the content (optional).
+//   - Data: Data to be used for populating the template (if applicable).
+//   - AutoPlainText: For Type == ContentHTML, derive a text/plain alternative
+//     from the HTML body and send it as a multipart/alternative part. Ignored
+//     if PlainText is set.
+//   - PlainText: For Type == ContentHTML, an explicit text/plain alternative
+//     to send instead of deriving one from the HTML body.
 type Content struct {
 	Type         string
 	Content      string
 	TemplatePath string
 	Data         interface{}
+
+	AutoPlainText bool
+	PlainText     string
 }
 
 // ConnectionDetails holds the configuration details required to establish
@@ -111,13 +140,43 @@ type ConnectionDetails struct {
 	SmtpPort     int
 	SmtpUser     string
 	SmtpPassword string
+
+	// Pool configures SMTP connection reuse for SendBatch and concurrent
+	// Send calls. The zero value disables pooling, so every Send dials a
+	// fresh connection via dialer.Dial and closes it after the message.
+	Pool ConnectionPoolOptions
+}
+
+// ConnectionPoolOptions configures how SMTP connections are reused instead
+// of dialed fresh for every message.
+type ConnectionPoolOptions struct {
+	// MaxConnections bounds how many connections the pool may hold open at
+	// once. Defaults to 1 when pooling is enabled.
+	MaxConnections int
+
+	// IdleTimeout is how long an idle connection may sit in the pool before
+	// it is considered stale and redialed on next use. Defaults to 5 minutes
+	// when pooling is enabled.
+	IdleTimeout time.Duration
+
+	// KeepAlive returns a connection to the pool after a successful send
+	// instead of closing it, so the next Send or SendBatch message reuses
+	// it. When false, every send closes its connection, but MaxConnections
+	// still caps concurrent dials.
+	KeepAlive bool
 }
 
 // sender defines an interface for sending emails.
-// It requires the implementation of a Send method, which takes a context
-// and an email as parameters and returns an error if the sending process fails.
+// It requires the implementation of a Send method, which takes a context,
+// an email, and the number of retry attempts, and returns an error if the
+// sending process fails after the configured retries are exhausted.
+//
+// Beyond the default SMTP-based gomailSender, MailgunSender, SendGridSender,
+// SESSender, and FakeSender each implement sender so a mailer can be pointed
+// at a different transport via NewWithSender without changing how Send is
+// called.
 type sender interface {
-	Send(ctx context.Context, e *email) error
+	Send(ctx context.Context, e *email, retry int) error
 }
 
 // New creates a new email instance using the provided connection details.
@@ -135,7 +194,25 @@ func New(c ConnectionDetails) (*email, error) {
 		return nil, err
 	}
 	dialer = gomail.NewDialer(c.SmtpHost, c.SmtpPort, c.SmtpUser, c.SmtpPassword)
-	return &email{dialer: dialer}, nil
+	e := &email{dialer: dialer}
+	if c.Pool.MaxConnections > 0 || c.Pool.IdleTimeout > 0 || c.Pool.KeepAlive {
+		e.pool = newConnPool(dialer, c.Pool)
+	}
+	return e, nil
+}
+
+// NewWithSender creates a new email instance that delivers through the given
+// sender implementation instead of the default SMTP dialer. Use this to route
+// mail through a vendor HTTP API backend such as MailgunSender, SendGridSender,
+// or SESSender, or through FakeSender for local development.
+//
+// Parameters:
+//   - s: The sender implementation responsible for delivering the email.
+//
+// Returns:
+//   - *email: A pointer to the initialized email instance.
+func NewWithSender(s sender) *email {
+	return &email{transport: s}
 }
 
 // validate checks the ConnectionDetails struct for missing or invalid fields.
@@ -193,6 +270,25 @@ func (e *email) validate() error {
 	if e.returnEmail != "" && len(e.returnEmail) > 500 {
 		return errors.New("returnEmail too long")
 	}
+
+	for _, f := range e.imagesToEmbed {
+		if f.path == "" {
+			continue
+		}
+		if _, err := os.Stat(f.path); err != nil {
+			return fmt.Errorf("embedded image %q: %w", f.path, err)
+		}
+	}
+
+	for _, f := range e.attachments {
+		if f.path == "" {
+			continue
+		}
+		if _, err := os.Stat(f.path); err != nil {
+			return fmt.Errorf("attachment %q: %w", f.path, err)
+		}
+	}
+
 	return nil
 }
 
@@ -202,15 +298,17 @@ func (e *email) validate() error {
 //   - ctx: The context for managing request deadlines, cancellations, and other values.
 //   - s: The sender implementation responsible for sending the email.
 //   - e: A pointer to the email object containing the email details.
+//   - retry: The number of retry attempts in case of failure.
 //
 // Returns:
 //   - An error if the email could not be sent, or nil if the operation was successful.
-func sendBy(ctx context.Context, s sender, e *email) error {
-	return s.Send(ctx, e)
+func sendBy(ctx context.Context, s sender, e *email, retry int) error {
+	return s.Send(ctx, e, retry)
 }
 
-// Send sends the email using the specified context and retry count.
-// It utilizes a gomailSender to handle the actual sending process.
+// Send sends the email using the specified context and retry count. It
+// routes through e.transport when the email was created with NewWithSender,
+// and falls back to the default gomailSender (SMTP) otherwise.
 //
 // Parameters:
 //   - ctx: The context to control the lifetime of the send operation.
@@ -219,7 +317,112 @@ func sendBy(ctx context.Context, s sender, e *email) error {
 // Returns:
 //   - An error if the email sending fails, or nil if successful.
 func (e *email) Send(ctx context.Context, retry int) error {
-	return sendBy(ctx, &gomailSender{retry: retry}, e)
+	s := e.transport
+	if s == nil {
+		s = &gomailSender{}
+	}
+	return sendBy(ctx, s, e, retry)
+}
+
+// SendBatch sends each of emails over a single reused SMTP connection
+// instead of dialing once per message, amortizing the handshake cost across
+// the batch. It uses e's connection pool if one was configured via
+// ConnectionDetails.Pool, or a temporary single-connection pool otherwise.
+// Every message is validated up front, and sending stops as soon as ctx is
+// canceled or a message fails.
+//
+// Parameters:
+//   - ctx: The context to control the lifetime of the batch send.
+//   - emails: The messages to send, in order, over the shared connection.
+//
+// Returns:
+//   - An error if any message fails validation or delivery, or if ctx is
+//     canceled before the batch completes.
+func (e *email) SendBatch(ctx context.Context, emails []*email) error {
+	for _, m := range emails {
+		if err := m.validate(); err != nil {
+			return err
+		}
+	}
+
+	pool := e.pool
+	if pool == nil {
+		pool = newConnPool(e.dialer, ConnectionPoolOptions{MaxConnections: 1, KeepAlive: true})
+		defer pool.Close()
+	}
+
+	for _, m := range emails {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		gm := getMessage(m)
+		from, to, err := messageEnvelope(gm)
+		if err != nil {
+			return err
+		}
+		if err := pool.send(ctx, from, to, gm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TestConnection verifies the configured SMTP connection end-to-end: it
+// dials the server, authenticates, and sends a minimal canned message to
+// to, mirroring the "send test email" check common in mail server admin
+// panels. It returns the underlying SMTP error verbatim, including the
+// server's textual reply, so operators can debug misconfiguration from a
+// startup check or health-check handler without building a full
+// MailDetails.
+//
+// Parameters:
+//   - ctx: The context controlling how long the connection attempt may take.
+//   - to: The recipient address the canned test message is sent to.
+//
+// Returns:
+//   - An error describing the failed dial, auth, or send step, or nil if the
+//     test message was delivered.
+func (e *email) TestConnection(ctx context.Context, to string) error {
+	sender := e.sender
+	if sender == "" && e.dialer != nil {
+		sender = e.dialer.Username
+	}
+	if sender == "" {
+		return errors.New("goemail: TestConnection requires a sender address; call SetSender first")
+	}
+
+	test := &email{dialer: e.dialer, transport: e.transport}
+	test.SetTo([]string{to})
+	test.SetSender(sender)
+	test.SetSenderName(e.senderName)
+	test.SetSubject("goemail test connection")
+	if err := test.SetContent(Content{
+		Type:    ContentText,
+		Content: "This is a test message sent by goemail's TestConnection to verify your mail configuration.",
+	}); err != nil {
+		return err
+	}
+
+	if err := test.Send(ctx, 1); err != nil {
+		if unwrapped := errors.Unwrap(err); unwrapped != nil {
+			return unwrapped
+		}
+		return err
+	}
+	return nil
+}
+
+// Close shuts down e's connection pool, if ConnectionDetails.Pool requested
+// one via New, closing every idle connection so a long-running service can
+// release them on shutdown. It is a no-op when e was not configured with a
+// pool.
+func (e *email) Close() error {
+	if e.pool == nil {
+		return nil
+	}
+	return e.pool.Close()
 }
 
 // SetTo sets the recipient email addresses for the email.
@@ -289,31 +492,69 @@ func (e *email) SetReturnEmail(returnEmail string) {
 
 // SetImagesToEmbed sets the list of image file paths to be embedded in the email.
 // The provided slice of strings should contain the file paths of the images
-// that need to be included as embedded content in the email.
+// that need to be included as embedded content in the email. Existence of
+// each path is checked by validate before the email is sent. To embed an
+// image read from memory or another non-file source, use EmbedReader instead.
 //
 // Parameters:
 //
 //	images - A slice of strings representing the file paths of the images to embed.
 func (e *email) SetImagesToEmbed(images []string) {
-	e.imagesToEmbed = images
+	e.imagesToEmbed = make([]fileAttachment, 0, len(images))
+	for _, path := range images {
+		e.imagesToEmbed = append(e.imagesToEmbed, newPathAttachment(path))
+	}
 }
 
 // SetAttachements sets the list of image file paths to be attached in the email.
 // The provided slice of strings should contain the file paths of the images
-// that need to be attached in the email.
+// that need to be attached in the email. Existence of each path is checked
+// by validate before the email is sent. To attach content read from memory
+// or another non-file source, use AttachReader instead.
 //
 // Parameters:
 //
 //	images - A slice of strings representing the file paths of the files to attached.
 func (e *email) SetAttachements(files []string) {
-	e.attachments = files
+	e.attachments = make([]fileAttachment, 0, len(files))
+	for _, path := range files {
+		e.attachments = append(e.attachments, newPathAttachment(path))
+	}
+}
+
+// AttachReader attaches content read from r under the given file name,
+// streaming it into the MIME message via gomail's SetCopyFunc instead of
+// requiring a file on disk.
+//
+// Parameters:
+//   - name: The file name reported in the attachment's headers.
+//   - r: The reader content is streamed from when the email is sent.
+//   - opts: Optional AttachOption values, e.g. WithContentType, WithContentID.
+func (e *email) AttachReader(name string, r io.Reader, opts ...AttachOption) {
+	e.attachments = append(e.attachments, newReaderAttachment(name, r, opts))
+}
+
+// EmbedReader embeds content read from r under the given Content-ID so it
+// can be referenced from an HTML body as cid:<cid>, streaming it into the
+// MIME message via gomail's SetCopyFunc instead of requiring a file on disk.
+//
+// Parameters:
+//   - cid: The Content-ID the image is referenced by, without angle brackets.
+//   - r: The reader content is streamed from when the email is sent.
+//   - opts: Optional AttachOption values, e.g. WithContentType.
+func (e *email) EmbedReader(cid string, r io.Reader, opts ...AttachOption) {
+	a := newReaderAttachment(cid, r, opts)
+	a.contentID = cid
+	e.imagesToEmbed = append(e.imagesToEmbed, a)
 }
 
 // SetContent sets the content of the email based on the provided Content struct.
 // It supports both HTML and plain text content types. For HTML content, it can
 // either use the provided content string or process a template file with the
 // given data. If neither content nor a valid template path is provided for HTML,
-// an error is returned.
+// an error is returned. For HTML content, it also derives a text/plain
+// alternative per content.AutoPlainText and content.PlainText so Send
+// composes a multipart/alternative message.
 //
 // Parameters:
 //   - content: A Content struct containing the type, content string, template path,
@@ -322,7 +563,7 @@ func (e *email) SetAttachements(files []string) {
 // Returns:
 //   - error: An error is returned if the content type is HTML and neither content
 //     nor a valid template path is provided, or if there is an issue processing
-//     the template.
+//     the template or deriving the plain-text alternative.
 func (e *email) SetContent(content Content) error {
 	e.contentType = content.Type
 	if content.Type == ContentHTML {
@@ -339,6 +580,16 @@ func (e *email) SetContent(content Content) error {
 			return fmt.Errorf("not content provided")
 		}
 
+		if content.PlainText != "" {
+			e.plainText = content.PlainText
+		} else if content.AutoPlainText {
+			text, err := htmlToText(e.content)
+			if err != nil {
+				return err
+			}
+			e.plainText = text
+		}
+
 	} else if content.Type == ContentText {
 		e.content = content.Content
 	}
@@ -0,0 +1,45 @@
+package goemail
+
+import (
+	"bytes"
+	"context"
+	"net/mail"
+	"testing"
+)
+
+// TestTestConnectionSendsACannedMessage verifies that TestConnection builds
+// and sends a well-formed canned message to the given recipient, using
+// e.transport so the send can be captured without a real SMTP dial.
+func TestTestConnectionSendsACannedMessage(t *testing.T) {
+	var buf bytes.Buffer
+	e := NewFakeSender(&buf)
+	e.SetSender("from@example.com")
+	e.SetSenderName("Example Sender")
+
+	if err := e.TestConnection(context.Background(), "to@example.com"); err != nil {
+		t.Fatalf("TestConnection returned error: %v", err)
+	}
+
+	msg, err := mail.ReadMessage(&buf)
+	if err != nil {
+		t.Fatalf("mail.ReadMessage: %v", err)
+	}
+	if got := msg.Header.Get("To"); got != "to@example.com" {
+		t.Errorf("To = %q, want %q", got, "to@example.com")
+	}
+	if got := msg.Header.Get("Subject"); got != "goemail test connection" {
+		t.Errorf("Subject = %q, want %q", got, "goemail test connection")
+	}
+	if got := msg.Header.Get("From"); got == "" {
+		t.Error("From header was not set")
+	}
+}
+
+// TestTestConnectionRequiresASender verifies that TestConnection refuses to
+// run without a configured sender address.
+func TestTestConnectionRequiresASender(t *testing.T) {
+	e := NewFakeSender(&bytes.Buffer{})
+	if err := e.TestConnection(context.Background(), "to@example.com"); err == nil {
+		t.Error("expected an error when no sender address is configured")
+	}
+}
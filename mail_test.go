@@ -0,0 +1,25 @@
+package goemail
+
+import "testing"
+
+func TestEmailCloseClosesThePool(t *testing.T) {
+	p := testPool(1)
+	c := &fakeSendCloser{}
+	p.idle = []*pooledConn{{SendCloser: c}}
+	p.open = 1
+
+	e := &email{pool: p}
+	if err := e.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	if !c.isClosed() {
+		t.Error("Close should close the pool's idle connections")
+	}
+}
+
+func TestEmailCloseWithoutPoolIsANoOp(t *testing.T) {
+	e := &email{}
+	if err := e.Close(); err != nil {
+		t.Errorf("Close on an email without a pool should be a no-op, got error: %v", err)
+	}
+}
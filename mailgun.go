@@ -0,0 +1,149 @@
+package goemail
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+)
+
+// MailgunSender delivers email through Mailgun's HTTP messages API
+// (https://documentation.mailgun.com/en/latest/api-sending.html#sending)
+// instead of SMTP. Construct one and pass it to NewWithSender.
+type MailgunSender struct {
+	// Domain is the Mailgun sending domain, e.g. "mg.example.com".
+	Domain string
+
+	// APIKey is the Mailgun private API key, sent as the password in the
+	// request's basic auth header.
+	APIKey string
+
+	// baseURL overrides the Mailgun API origin ("https://api.mailgun.net" by
+	// default). It exists so tests can point sendOnce at an httptest.Server.
+	baseURL string
+}
+
+// mailgunBaseURL is the production Mailgun API origin.
+const mailgunBaseURL = "https://api.mailgun.net"
+
+// Send validates e and retries delivery through the Mailgun messages API
+// using the package's exponential backoff policy.
+func (m *MailgunSender) Send(ctx context.Context, e *email, retry int) error {
+	if err := e.validate(); err != nil {
+		return err
+	}
+	return exponentialBackOffRetry(ctx, e, func(e *email) error {
+		return m.sendOnce(ctx, e)
+	}, DefaultRetryPolicy(retry))
+}
+
+// sendOnce performs a single, non-retried call to the Mailgun messages API.
+// The message is sent as multipart/form-data rather than a plain form body
+// so attachments and embedded images can ride along as file parts.
+func (m *MailgunSender) sendOnce(ctx context.Context, e *email) error {
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+
+	if err := w.WriteField("from", formatAddress(e.sender, e.senderName)); err != nil {
+		return err
+	}
+	for _, v := range e.to {
+		if err := w.WriteField("to", v); err != nil {
+			return err
+		}
+	}
+	for _, v := range e.cc {
+		if err := w.WriteField("cc", v); err != nil {
+			return err
+		}
+	}
+	for _, v := range e.bcc {
+		if err := w.WriteField("bcc", v); err != nil {
+			return err
+		}
+	}
+	if err := w.WriteField("subject", e.subject); err != nil {
+		return err
+	}
+	if e.contentType == ContentHTML {
+		if err := w.WriteField("html", e.content); err != nil {
+			return err
+		}
+		if e.plainText != "" {
+			if err := w.WriteField("text", e.plainText); err != nil {
+				return err
+			}
+		}
+	} else {
+		if err := w.WriteField("text", e.content); err != nil {
+			return err
+		}
+	}
+	if e.tags != "" {
+		if err := w.WriteField("o:tag", e.tags); err != nil {
+			return err
+		}
+	}
+
+	for _, f := range e.attachments {
+		if err := writeMailgunFile(w, "attachment", f); err != nil {
+			return err
+		}
+	}
+	for _, f := range e.imagesToEmbed {
+		if err := writeMailgunFile(w, "inline", f); err != nil {
+			return err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	base := m.baseURL
+	if base == "" {
+		base = mailgunBaseURL
+	}
+	endpoint := fmt.Sprintf("%s/v3/%s/messages", base, m.Domain)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	req.SetBasicAuth("api", m.APIKey)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return &httpStatusError{provider: "mailgun", status: resp.StatusCode, body: string(respBody)}
+	}
+	return nil
+}
+
+// writeMailgunFile adds f as a Mailgun file part under field (either
+// "attachment" or "inline", matching Mailgun's API), with f's content-type
+// if one was set.
+func writeMailgunFile(w *multipart.Writer, field string, f fileAttachment) error {
+	contentType := f.contentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name=%q; filename=%q`, field, f.name))
+	header.Set("Content-Type", contentType)
+
+	part, err := w.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	return f.writeTo(part)
+}
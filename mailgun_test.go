@@ -0,0 +1,138 @@
+package goemail
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTempFile writes content to a temp file named name under t's temp
+// directory and returns its path, for exercising path-based attachments
+// (SetAttachements/SetImagesToEmbed) without touching the real filesystem
+// outside the test.
+func writeTempFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("writing temp file: %v", err)
+	}
+	return path
+}
+
+func TestMailgunSenderRequestShape(t *testing.T) {
+	var (
+		gotMethod string
+		gotAuth   string
+		form      *multipartForm
+	)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		_, gotAuth, _ = r.BasicAuth()
+		form = parseMultipartForm(t, r)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	sender := &MailgunSender{Domain: "mg.example.com", APIKey: "test-key", baseURL: ts.URL}
+	e := NewWithSender(sender)
+	e.SetSender("from@example.com")
+	e.SetSenderName("Example Sender")
+	e.SetTo([]string{"to@example.com"})
+	e.SetCC([]string{"cc@example.com"})
+	e.SetSubject("Hello")
+	e.SetTags("welcome")
+	if err := e.SetContent(Content{
+		Type:          ContentHTML,
+		Content:       "<p>Hi there</p>",
+		AutoPlainText: true,
+	}); err != nil {
+		t.Fatalf("SetContent returned error: %v", err)
+	}
+	e.SetAttachements([]string{writeTempFile(t, "report.txt", "report body")})
+	e.EmbedReader("logo", strings.NewReader("logo bytes"))
+
+	if err := e.Send(context.Background(), 1); err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+
+	if gotMethod != http.MethodPost {
+		t.Errorf("method = %q, want POST", gotMethod)
+	}
+	if gotAuth != "test-key" {
+		t.Errorf("basic auth password = %q, want %q", gotAuth, "test-key")
+	}
+	if got := form.value("from"); got != "Example Sender <from@example.com>" {
+		t.Errorf(`from = %q, want "Example Sender <from@example.com>"`, got)
+	}
+	if got := form.value("to"); got != "to@example.com" {
+		t.Errorf("to = %q, want %q", got, "to@example.com")
+	}
+	if got := form.value("cc"); got != "cc@example.com" {
+		t.Errorf("cc = %q, want %q", got, "cc@example.com")
+	}
+	if got := form.value("html"); got != "<p>Hi there</p>" {
+		t.Errorf("html = %q, want %q", got, "<p>Hi there</p>")
+	}
+	if got := form.value("text"); got == "" {
+		t.Error("text (plain-text alternative) was not sent alongside html")
+	}
+	if got := form.value("o:tag"); got != "welcome" {
+		t.Errorf("o:tag = %q, want %q", got, "welcome")
+	}
+	if got := form.fileContent("attachment"); got != "report body" {
+		t.Errorf("attachment content = %q, want %q", got, "report body")
+	}
+	if got := form.fileContent("inline"); got != "logo bytes" {
+		t.Errorf("inline content = %q, want %q", got, "logo bytes")
+	}
+}
+
+// multipartForm holds the parsed fields and files from a multipart/form-data
+// request, for asserting on in HTTP sender tests.
+type multipartForm struct {
+	values map[string][]string
+	files  map[string][]byte
+}
+
+func (f *multipartForm) value(key string) string {
+	if len(f.values[key]) == 0 {
+		return ""
+	}
+	return f.values[key][0]
+}
+
+func (f *multipartForm) fileContent(field string) string {
+	return string(f.files[field])
+}
+
+// parseMultipartForm reads and parses r's multipart/form-data body, failing
+// the test on any error.
+func parseMultipartForm(t *testing.T, r *http.Request) *multipartForm {
+	t.Helper()
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		t.Fatalf("ParseMultipartForm: %v", err)
+	}
+	out := &multipartForm{values: r.MultipartForm.Value, files: map[string][]byte{}}
+	for field, headers := range r.MultipartForm.File {
+		if len(headers) == 0 {
+			continue
+		}
+		fh, err := headers[0].Open()
+		if err != nil {
+			t.Fatalf("opening file part %q: %v", field, err)
+		}
+		data, err := io.ReadAll(fh)
+		fh.Close()
+		if err != nil {
+			t.Fatalf("reading file part %q: %v", field, err)
+		}
+		out.files[field] = data
+	}
+	return out
+}
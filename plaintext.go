@@ -0,0 +1,97 @@
+package goemail
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// htmlToText converts an HTML fragment into a readable plain-text
+// approximation: tags are stripped, links are unwrapped as "text (url)", and
+// paragraph-level elements (p, div, br, headings, list items) force a line
+// break so paragraph structure survives.
+func htmlToText(h string) (string, error) {
+	doc, err := html.Parse(strings.NewReader(h))
+	if err != nil {
+		return "", err
+	}
+
+	var buf strings.Builder
+	walkHTMLToText(doc, &buf)
+
+	return collapseBlankLines(buf.String()), nil
+}
+
+// walkHTMLToText recurses through n's node tree, writing text content to buf
+// inserting a paragraph break after block-level elements and a line break
+// for br, and writing links inline as "text (url)".
+func walkHTMLToText(n *html.Node, buf *strings.Builder) {
+	switch n.Type {
+	case html.TextNode:
+		buf.WriteString(n.Data)
+	case html.ElementNode:
+		switch n.Data {
+		case "script", "style":
+			return
+		case "br":
+			buf.WriteString("\n")
+			return
+		case "a":
+			href := attr(n, "href")
+			var linkText strings.Builder
+			for c := n.FirstChild; c != nil; c = c.NextSibling {
+				walkHTMLToText(c, &linkText)
+			}
+			text := strings.TrimSpace(linkText.String())
+			if href != "" && href != text {
+				buf.WriteString(text + " (" + href + ")")
+			} else {
+				buf.WriteString(text)
+			}
+			return
+		}
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		walkHTMLToText(c, buf)
+	}
+
+	if n.Type == html.ElementNode {
+		switch n.Data {
+		case "p", "div", "li", "h1", "h2", "h3", "h4", "h5", "h6", "tr":
+			buf.WriteString("\n\n")
+		}
+	}
+}
+
+// attr returns the value of the named attribute on n, or "" if absent.
+func attr(n *html.Node, name string) string {
+	for _, a := range n.Attr {
+		if a.Key == name {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// collapseBlankLines trims trailing whitespace from each line, collapses
+// every run of two or more blank lines down to a single blank line (one
+// paragraph break), and trims leading and trailing newlines.
+func collapseBlankLines(s string) string {
+	lines := strings.Split(s, "\n")
+	var out []string
+	blank := 0
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		if strings.TrimSpace(line) == "" {
+			blank++
+			if blank > 1 {
+				continue
+			}
+		} else {
+			blank = 0
+		}
+		out = append(out, line)
+	}
+	return strings.Trim(strings.Join(out, "\n"), "\n")
+}
@@ -0,0 +1,54 @@
+package goemail
+
+import "testing"
+
+func TestHtmlToText(t *testing.T) {
+	cases := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "paragraphs",
+			html: "<p>Hello there.</p><p>Second paragraph.</p>",
+			want: "Hello there.\n\nSecond paragraph.",
+		},
+		{
+			name: "line break",
+			html: "Line one<br>Line two",
+			want: "Line one\nLine two",
+		},
+		{
+			name: "link unwrapped with url",
+			html: `<p>See <a href="https://example.com">our site</a> for more.</p>`,
+			want: "See our site (https://example.com) for more.",
+		},
+		{
+			name: "link matching its own href is not duplicated",
+			html: `<a href="https://example.com">https://example.com</a>`,
+			want: "https://example.com",
+		},
+		{
+			name: "script and style content is dropped",
+			html: "<p>Visible</p><script>alert('x')</script><style>p{color:red}</style>",
+			want: "Visible",
+		},
+		{
+			name: "list items each get a line",
+			html: "<ul><li>One</li><li>Two</li></ul>",
+			want: "One\n\nTwo",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := htmlToText(tc.html)
+			if err != nil {
+				t.Fatalf("htmlToText returned error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("htmlToText(%q) = %q, want %q", tc.html, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package goemail
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/textproto"
+	"sync"
+	"time"
+
+	"gopkg.in/gomail.v2"
+)
+
+// connPool manages a bounded set of open SMTP connections so repeated
+// Send/SendBatch calls can reuse a handshake instead of dialing fresh every
+// time. It is created by New when ConnectionDetails.Pool requests pooling,
+// or ad hoc by SendBatch when pooling wasn't configured.
+type connPool struct {
+	dialer      *gomail.Dialer
+	maxConns    int
+	idleTimeout time.Duration
+	keepAlive   bool
+
+	mu      sync.Mutex
+	idle    []*pooledConn
+	open    int
+	closed  bool
+	waiters chan struct{}
+}
+
+// pooledConn is a single SMTP connection held by a connPool, tracking when it
+// was last returned to the pool so get can evict stale connections.
+type pooledConn struct {
+	gomail.SendCloser
+	lastUsed time.Time
+}
+
+// defaultIdleTimeout is used when ConnectionPoolOptions.IdleTimeout is unset.
+const defaultIdleTimeout = 5 * time.Minute
+
+// newConnPool builds a connPool from the given dialer and options, applying
+// defaults for any zero-valued fields.
+func newConnPool(dialer *gomail.Dialer, opts ConnectionPoolOptions) *connPool {
+	maxConns := opts.MaxConnections
+	if maxConns <= 0 {
+		maxConns = 1
+	}
+	idleTimeout := opts.IdleTimeout
+	if idleTimeout <= 0 {
+		idleTimeout = defaultIdleTimeout
+	}
+	return &connPool{
+		dialer:      dialer,
+		maxConns:    maxConns,
+		idleTimeout: idleTimeout,
+		keepAlive:   opts.KeepAlive,
+		waiters:     make(chan struct{}),
+	}
+}
+
+// send delivers gm from from to to over a pooled connection, dialing one if
+// needed (waiting for one to free up if the pool is already at
+// MaxConnections), and returns it to the pool (or closes it) depending on
+// the outcome.
+//
+// It calls the pooled SendCloser's Send method directly instead of
+// gomail.Send, which wraps the underlying error in a plain fmt.Errorf and
+// would make isConnectionError unable to recognize it below.
+func (p *connPool) send(ctx context.Context, from string, to []string, gm *gomail.Message) error {
+	c, err := p.get(ctx)
+	if err != nil {
+		return err
+	}
+	sendErr := c.Send(from, to, gm)
+	p.put(c, sendErr)
+	return sendErr
+}
+
+// get returns an idle connection that is still within IdleTimeout, dialing a
+// new one if none qualify and the pool has room under MaxConnections.
+// Otherwise it blocks until a connection is returned or evicted by another
+// caller, or ctx is done.
+func (p *connPool) get(ctx context.Context) (*pooledConn, error) {
+	for {
+		p.mu.Lock()
+		if p.closed {
+			p.mu.Unlock()
+			return nil, errors.New("goemail: connection pool is closed")
+		}
+		for len(p.idle) > 0 {
+			c := p.idle[len(p.idle)-1]
+			p.idle = p.idle[:len(p.idle)-1]
+			if time.Since(c.lastUsed) > p.idleTimeout {
+				c.Close()
+				p.open--
+				continue
+			}
+			p.mu.Unlock()
+			return c, nil
+		}
+
+		if p.open < p.maxConns {
+			p.open++
+			p.mu.Unlock()
+
+			sc, err := p.dialer.Dial()
+			if err != nil {
+				p.mu.Lock()
+				p.open--
+				p.notifyLocked()
+				p.mu.Unlock()
+				return nil, err
+			}
+			return &pooledConn{SendCloser: sc, lastUsed: time.Now()}, nil
+		}
+
+		wait := p.waiters
+		p.mu.Unlock()
+
+		select {
+		case <-wait:
+			// A connection was freed or evicted; loop around and retry.
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// put returns c to the pool after a send, unless the pool is configured to
+// close connections after every send (KeepAlive false) or the send failed
+// with an error that indicates the connection itself is no longer usable.
+func (p *connPool) put(c *pooledConn, sendErr error) {
+	if !p.keepAlive || isConnectionError(sendErr) {
+		c.Close()
+		p.mu.Lock()
+		p.open--
+		p.notifyLocked()
+		p.mu.Unlock()
+		return
+	}
+
+	c.lastUsed = time.Now()
+	p.mu.Lock()
+	p.idle = append(p.idle, c)
+	p.notifyLocked()
+	p.mu.Unlock()
+}
+
+// notifyLocked wakes every caller blocked in get, waiting for a connection to
+// free up. p.mu must already be held.
+func (p *connPool) notifyLocked() {
+	close(p.waiters)
+	p.waiters = make(chan struct{})
+}
+
+// Close closes every idle connection held by the pool. It does not affect
+// connections currently checked out by an in-flight send.
+func (p *connPool) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.closed = true
+	var firstErr error
+	for _, c := range p.idle {
+		if err := c.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		p.open--
+	}
+	p.idle = nil
+	p.notifyLocked()
+	return firstErr
+}
+
+// isConnectionError reports whether err indicates the underlying SMTP
+// connection is unusable and should be closed rather than pooled: a closed
+// connection (io.EOF) or any 4xx or 5xx SMTP reply, transient or permanent.
+func isConnectionError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, io.EOF) {
+		return true
+	}
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) && protoErr.Code >= 400 {
+		return true
+	}
+	return false
+}
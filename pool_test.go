@@ -0,0 +1,208 @@
+package goemail
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/textproto"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeSendCloser is a minimal gomail.SendCloser double for exercising
+// connPool without dialing a real SMTP connection.
+type fakeSendCloser struct {
+	mu     sync.Mutex
+	closed bool
+	sent   bool
+}
+
+func (f *fakeSendCloser) Send(from string, to []string, msg io.WriterTo) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = true
+	return nil
+}
+
+func (f *fakeSendCloser) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = true
+	return nil
+}
+
+func (f *fakeSendCloser) isClosed() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.closed
+}
+
+func testPool(maxConns int) *connPool {
+	return newConnPool(nil, ConnectionPoolOptions{MaxConnections: maxConns, KeepAlive: true})
+}
+
+func TestConnPoolGetBlocksUntilConnectionIsFreed(t *testing.T) {
+	p := testPool(1)
+	p.open = 1 // simulate the single allowed connection already checked out
+
+	type result struct {
+		c   *pooledConn
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		c, err := p.get(context.Background())
+		done <- result{c, err}
+	}()
+
+	select {
+	case r := <-done:
+		t.Fatalf("get returned early with (%v, %v) before any connection was freed", r.c, r.err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	freed := &fakeSendCloser{}
+	p.put(&pooledConn{SendCloser: freed, lastUsed: time.Now()}, nil)
+
+	select {
+	case r := <-done:
+		if r.err != nil {
+			t.Fatalf("get returned error: %v", r.err)
+		}
+		if r.c.SendCloser != freed {
+			t.Error("get did not return the connection that was freed")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("get did not unblock after a connection was freed")
+	}
+}
+
+func TestConnPoolGetFailsFastWhenContextAlreadyDone(t *testing.T) {
+	p := testPool(1)
+	p.open = 1 // no room, and nothing will ever free up in this test
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := p.get(ctx); err == nil {
+		t.Fatal("expected get to return an error once ctx is done")
+	}
+}
+
+func TestConnPoolGetEvictsExpiredIdleConnections(t *testing.T) {
+	p := testPool(2)
+
+	expired := &fakeSendCloser{}
+	fresh := &fakeSendCloser{}
+	p.idle = []*pooledConn{
+		{SendCloser: fresh, lastUsed: time.Now()},
+		{SendCloser: expired, lastUsed: time.Now().Add(-2 * p.idleTimeout)},
+	}
+	p.open = 2
+
+	c, err := p.get(context.Background())
+	if err != nil {
+		t.Fatalf("get returned error: %v", err)
+	}
+	if c.SendCloser != fresh {
+		t.Error("get should have skipped the expired connection and returned the fresh one")
+	}
+	if !expired.isClosed() {
+		t.Error("expired connection should have been closed during eviction")
+	}
+	if p.open != 1 {
+		t.Errorf("open = %d, want 1 after evicting the expired connection", p.open)
+	}
+}
+
+func TestConnPoolPutClosesConnectionWhenKeepAliveDisabled(t *testing.T) {
+	p := newConnPool(nil, ConnectionPoolOptions{MaxConnections: 1, KeepAlive: false})
+	p.open = 1
+
+	c := &fakeSendCloser{}
+	p.put(&pooledConn{SendCloser: c, lastUsed: time.Now()}, nil)
+
+	if !c.isClosed() {
+		t.Error("put should close the connection when KeepAlive is false")
+	}
+	if len(p.idle) != 0 {
+		t.Error("put should not return the connection to idle when KeepAlive is false")
+	}
+	if p.open != 0 {
+		t.Errorf("open = %d, want 0 after put closed the connection", p.open)
+	}
+}
+
+func TestIsConnectionError(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"EOF", io.EOF, true},
+		{"permanent SMTP reply", &textproto.Error{Code: 550, Msg: "mailbox unavailable"}, true},
+		{"4xx SMTP reply", &textproto.Error{Code: 421, Msg: "service not available"}, true},
+		{"SMTP reply below 400", &textproto.Error{Code: 250, Msg: "ok"}, false},
+		{"other error", errors.New("boom"), false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isConnectionError(tc.err); got != tc.want {
+				t.Errorf("isConnectionError(%v) = %v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestConnPoolSendUsesSendCloserDirectly(t *testing.T) {
+	p := testPool(1)
+	c := &fakeSendCloser{}
+	p.idle = []*pooledConn{{SendCloser: c, lastUsed: time.Now()}}
+	p.open = 1
+
+	gm := getMessage(&email{sender: "from@example.com", to: []string{"to@example.com"}, subject: "hi", contentType: ContentText, content: "body"})
+	if err := p.send(context.Background(), "from@example.com", []string{"to@example.com"}, gm); err != nil {
+		t.Fatalf("send returned error: %v", err)
+	}
+	if !c.sent {
+		t.Error("send should have called the pooled connection's Send method directly")
+	}
+}
+
+func TestConnPoolGetFailsAfterClose(t *testing.T) {
+	p := testPool(2)
+
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	if _, err := p.get(context.Background()); err == nil {
+		t.Fatal("expected get to return an error once the pool is closed")
+	}
+}
+
+func TestConnPoolCloseClosesIdleConnections(t *testing.T) {
+	p := testPool(2)
+	a := &fakeSendCloser{}
+	b := &fakeSendCloser{}
+	p.idle = []*pooledConn{
+		{SendCloser: a, lastUsed: time.Now()},
+		{SendCloser: b, lastUsed: time.Now()},
+	}
+	p.open = 2
+
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	if !a.isClosed() || !b.isClosed() {
+		t.Error("Close should close every idle connection")
+	}
+	if len(p.idle) != 0 {
+		t.Errorf("idle = %d entries, want 0 after Close", len(p.idle))
+	}
+	if p.open != 0 {
+		t.Errorf("open = %d, want 0 after Close", p.open)
+	}
+}
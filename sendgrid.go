@@ -0,0 +1,184 @@
+package goemail
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// SendGridSender delivers email through SendGrid's v3 mail/send API
+// (https://docs.sendgrid.com/api-reference/mail-send/mail-send) instead of
+// SMTP. Construct one and pass it to NewWithSender.
+type SendGridSender struct {
+	// APIKey is the SendGrid API key, sent as a bearer token.
+	APIKey string
+
+	// baseURL overrides the SendGrid API origin ("https://api.sendgrid.com"
+	// by default). It exists so tests can point sendOnce at an
+	// httptest.Server.
+	baseURL string
+}
+
+// sendGridBaseURL is the production SendGrid API origin.
+const sendGridBaseURL = "https://api.sendgrid.com"
+
+// sendGridAddress is a SendGrid "email object" (address plus optional name).
+type sendGridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+// sendGridPersonalization lists the recipients for a single SendGrid message.
+type sendGridPersonalization struct {
+	To  []sendGridAddress `json:"to"`
+	Cc  []sendGridAddress `json:"cc,omitempty"`
+	Bcc []sendGridAddress `json:"bcc,omitempty"`
+}
+
+// sendGridContent is one body part of a SendGrid message.
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// sendGridAttachment is one file attached to, or embedded in, a SendGrid
+// message, carried as a base64-encoded blob.
+type sendGridAttachment struct {
+	Content     string `json:"content"`
+	Filename    string `json:"filename"`
+	Type        string `json:"type,omitempty"`
+	Disposition string `json:"disposition,omitempty"`
+	ContentID   string `json:"content_id,omitempty"`
+}
+
+// sendGridMessage is the request body for SendGrid's v3 mail/send endpoint.
+type sendGridMessage struct {
+	Personalizations []sendGridPersonalization `json:"personalizations"`
+	From             sendGridAddress           `json:"from"`
+	Subject          string                    `json:"subject"`
+	Content          []sendGridContent         `json:"content"`
+	Attachments      []sendGridAttachment      `json:"attachments,omitempty"`
+}
+
+// Send validates e and retries delivery through the SendGrid mail/send API
+// using the package's exponential backoff policy.
+func (s *SendGridSender) Send(ctx context.Context, e *email, retry int) error {
+	if err := e.validate(); err != nil {
+		return err
+	}
+	return exponentialBackOffRetry(ctx, e, func(e *email) error {
+		return s.sendOnce(ctx, e)
+	}, DefaultRetryPolicy(retry))
+}
+
+// sendOnce performs a single, non-retried call to the SendGrid mail/send API.
+func (s *SendGridSender) sendOnce(ctx context.Context, e *email) error {
+	var content []sendGridContent
+	if e.contentType == ContentHTML {
+		if e.plainText != "" {
+			content = append(content, sendGridContent{Type: "text/plain", Value: e.plainText})
+		}
+		content = append(content, sendGridContent{Type: "text/html", Value: e.content})
+	} else {
+		content = append(content, sendGridContent{Type: "text/plain", Value: e.content})
+	}
+
+	attachments, err := sendGridAttachments(e.attachments, false)
+	if err != nil {
+		return err
+	}
+	inline, err := sendGridAttachments(e.imagesToEmbed, true)
+	if err != nil {
+		return err
+	}
+	attachments = append(attachments, inline...)
+
+	msg := sendGridMessage{
+		Personalizations: []sendGridPersonalization{{
+			To:  sendGridAddresses(e.to),
+			Cc:  sendGridAddresses(e.cc),
+			Bcc: sendGridAddresses(e.bcc),
+		}},
+		From:        sendGridAddress{Email: e.sender, Name: e.senderName},
+		Subject:     e.subject,
+		Content:     content,
+		Attachments: attachments,
+	}
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	base := s.baseURL
+	if base == "" {
+		base = sendGridBaseURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, base+"/v3/mail/send", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.APIKey)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return &httpStatusError{provider: "sendgrid", status: resp.StatusCode, body: string(respBody)}
+	}
+	return nil
+}
+
+// sendGridAddresses converts plain addresses into SendGrid email objects.
+func sendGridAddresses(addresses []string) []sendGridAddress {
+	if len(addresses) == 0 {
+		return nil
+	}
+	out := make([]sendGridAddress, 0, len(addresses))
+	for _, a := range addresses {
+		out = append(out, sendGridAddress{Email: a})
+	}
+	return out
+}
+
+// sendGridAttachments converts fileAttachments into SendGrid's base64
+// attachment objects, marking them "inline" (referenceable via content_id)
+// when inline is true, or plain "attachment" otherwise.
+func sendGridAttachments(files []fileAttachment, inline bool) ([]sendGridAttachment, error) {
+	if len(files) == 0 {
+		return nil, nil
+	}
+	out := make([]sendGridAttachment, 0, len(files))
+	for _, f := range files {
+		data, err := f.bytes()
+		if err != nil {
+			return nil, err
+		}
+		a := sendGridAttachment{
+			Content:     base64.StdEncoding.EncodeToString(data),
+			Filename:    f.name,
+			Type:        f.contentType,
+			Disposition: "attachment",
+		}
+		if inline {
+			a.Disposition = "inline"
+			cid := f.contentID
+			if cid == "" {
+				cid = f.name
+			}
+			a.ContentID = cid
+		} else if f.contentID != "" {
+			a.ContentID = f.contentID
+		}
+		out = append(out, a)
+	}
+	return out, nil
+}
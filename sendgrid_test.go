@@ -0,0 +1,92 @@
+package goemail
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSendGridSenderRequestShape(t *testing.T) {
+	var (
+		gotAuth string
+		gotMsg  sendGridMessage
+	)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&gotMsg); err != nil {
+			t.Fatalf("decoding request body: %v", err)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer ts.Close()
+
+	sender := &SendGridSender{APIKey: "test-key", baseURL: ts.URL}
+	e := NewWithSender(sender)
+	e.SetSender("from@example.com")
+	e.SetSenderName("Example Sender")
+	e.SetTo([]string{"to@example.com"})
+	e.SetSubject("Hello")
+	if err := e.SetContent(Content{
+		Type:          ContentHTML,
+		Content:       "<p>Hi there</p>",
+		AutoPlainText: true,
+	}); err != nil {
+		t.Fatalf("SetContent returned error: %v", err)
+	}
+	e.SetAttachements([]string{writeTempFile(t, "report.txt", "report body")})
+	e.EmbedReader("logo", strings.NewReader("logo bytes"))
+
+	if err := e.Send(context.Background(), 1); err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+
+	if gotAuth != "Bearer test-key" {
+		t.Errorf("Authorization = %q, want %q", gotAuth, "Bearer test-key")
+	}
+	if len(gotMsg.Personalizations) != 1 || len(gotMsg.Personalizations[0].To) != 1 || gotMsg.Personalizations[0].To[0].Email != "to@example.com" {
+		t.Errorf("personalizations = %+v, want a single entry addressed to to@example.com", gotMsg.Personalizations)
+	}
+	if gotMsg.From.Email != "from@example.com" || gotMsg.From.Name != "Example Sender" {
+		t.Errorf("from = %+v, want {from@example.com Example Sender}", gotMsg.From)
+	}
+	if len(gotMsg.Content) != 2 {
+		t.Fatalf("content = %+v, want both a text/plain and text/html entry", gotMsg.Content)
+	}
+	if gotMsg.Content[0].Type != "text/plain" || gotMsg.Content[1].Type != "text/html" {
+		t.Errorf("content types = %q, %q, want text/plain then text/html", gotMsg.Content[0].Type, gotMsg.Content[1].Type)
+	}
+	if gotMsg.Content[1].Value != "<p>Hi there</p>" {
+		t.Errorf("html content = %q, want %q", gotMsg.Content[1].Value, "<p>Hi there</p>")
+	}
+
+	var attachment, inline *sendGridAttachment
+	for i := range gotMsg.Attachments {
+		a := &gotMsg.Attachments[i]
+		switch a.Disposition {
+		case "attachment":
+			attachment = a
+		case "inline":
+			inline = a
+		}
+	}
+	if attachment == nil {
+		t.Fatal("no attachment-disposition file in the request")
+	}
+	if decoded, _ := base64.StdEncoding.DecodeString(attachment.Content); string(decoded) != "report body" {
+		t.Errorf("attachment content = %q, want %q", decoded, "report body")
+	}
+	if inline == nil {
+		t.Fatal("no inline-disposition file in the request")
+	}
+	if decoded, _ := base64.StdEncoding.DecodeString(inline.Content); string(decoded) != "logo bytes" {
+		t.Errorf("inline content = %q, want %q", decoded, "logo bytes")
+	}
+	if inline.ContentID != "logo" {
+		t.Errorf("inline content_id = %q, want %q", inline.ContentID, "logo")
+	}
+}
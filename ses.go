@@ -0,0 +1,214 @@
+package goemail
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// SESSender delivers email through the AWS SES v2 SendEmail API
+// (https://docs.aws.amazon.com/ses/latest/APIReference-V2/API_SendEmail.html)
+// instead of SMTP. Construct one and pass it to NewWithSender.
+type SESSender struct {
+	// Region is the AWS region the SES endpoint is served from, e.g. "us-east-1".
+	Region string
+
+	// AccessKeyID and SecretAccessKey are the AWS credentials used to sign
+	// requests with SigV4.
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// baseURL overrides the request's scheme and host
+	// ("https://email.<Region>.amazonaws.com" by default); the Host header
+	// used for signing is unaffected. It exists so tests can point sendOnce
+	// at an httptest.Server while still signing as the real SES endpoint.
+	baseURL string
+}
+
+// sesBody is one text or HTML body part in a SES v2 "Simple" message.
+type sesBody struct {
+	Data string `json:"Data"`
+}
+
+// sesContent is the Simple email body for a SES v2 SendEmail request. It is
+// only used when e carries no attachments or embedded images; messages that
+// do are sent as Raw MIME instead, since Simple has no attachment support.
+type sesContent struct {
+	Subject sesBody `json:"Subject"`
+	Body    struct {
+		Html *sesBody `json:"Html,omitempty"`
+		Text *sesBody `json:"Text,omitempty"`
+	} `json:"Body"`
+}
+
+// sesRawMessage is a full MIME message for a SES v2 "Raw" SendEmail request,
+// used whenever attachments or embedded images are present.
+type sesRawMessage struct {
+	Data string `json:"Data"`
+}
+
+// sesContentWrapper holds exactly one of Simple or Raw, matching SES v2's
+// Content union.
+type sesContentWrapper struct {
+	Simple *sesContent    `json:"Simple,omitempty"`
+	Raw    *sesRawMessage `json:"Raw,omitempty"`
+}
+
+// sesSendEmailInput is the request body for the SES v2 SendEmail operation.
+type sesSendEmailInput struct {
+	FromEmailAddress string `json:"FromEmailAddress"`
+	Destination      struct {
+		ToAddresses  []string `json:"ToAddresses,omitempty"`
+		CcAddresses  []string `json:"CcAddresses,omitempty"`
+		BccAddresses []string `json:"BccAddresses,omitempty"`
+	} `json:"Destination"`
+	Content sesContentWrapper `json:"Content"`
+}
+
+// Send validates e and retries delivery through the SES v2 SendEmail API
+// using the package's exponential backoff policy.
+func (s *SESSender) Send(ctx context.Context, e *email, retry int) error {
+	if err := e.validate(); err != nil {
+		return err
+	}
+	return exponentialBackOffRetry(ctx, e, func(e *email) error {
+		return s.sendOnce(ctx, e)
+	}, DefaultRetryPolicy(retry))
+}
+
+// sendOnce performs a single, non-retried, SigV4-signed call to the SES v2
+// SendEmail API.
+func (s *SESSender) sendOnce(ctx context.Context, e *email) error {
+	var input sesSendEmailInput
+	input.FromEmailAddress = formatAddress(e.sender, e.senderName)
+	input.Destination.ToAddresses = e.to
+	input.Destination.CcAddresses = e.cc
+	input.Destination.BccAddresses = e.bcc
+
+	if len(e.attachments) > 0 || len(e.imagesToEmbed) > 0 {
+		raw, err := sesRawMIME(e)
+		if err != nil {
+			return err
+		}
+		input.Content.Raw = &sesRawMessage{Data: raw}
+	} else {
+		input.Content.Simple = sesSimpleContent(e)
+	}
+
+	body, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+
+	host := fmt.Sprintf("email.%s.amazonaws.com", s.Region)
+	base := s.baseURL
+	if base == "" {
+		base = "https://" + host
+	}
+	endpoint := base + "/v2/email/outbound-emails"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Host = host
+
+	s.sign(req, body, time.Now().UTC())
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return &httpStatusError{provider: "ses", status: resp.StatusCode, body: string(respBody)}
+	}
+	return nil
+}
+
+// sesSimpleContent builds the SES v2 "Simple" content for e, including both
+// the HTML body and its text/plain alternative when e.plainText is set.
+func sesSimpleContent(e *email) *sesContent {
+	c := &sesContent{Subject: sesBody{Data: e.subject}}
+	if e.contentType == ContentHTML {
+		c.Body.Html = &sesBody{Data: e.content}
+		if e.plainText != "" {
+			c.Body.Text = &sesBody{Data: e.plainText}
+		}
+	} else {
+		c.Body.Text = &sesBody{Data: e.content}
+	}
+	return c
+}
+
+// sesRawMIME renders e as a full MIME message the same way the SMTP sender
+// would (including attachments, embedded images, and any plain-text
+// alternative), and returns it base64-encoded for SES v2's Raw content.
+func sesRawMIME(e *email) (string, error) {
+	gm := getMessage(e)
+	var buf bytes.Buffer
+	if _, err := gm.WriteTo(&buf); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// sign attaches AWS SigV4 "Authorization" and "X-Amz-Date" headers for the
+// "ses" service, following the canonical request algorithm described at
+// https://docs.aws.amazon.com/general/latest/gr/sigv4_signing.html.
+func (s *SESSender) sign(req *http.Request, body []byte, now time.Time) {
+	const service = "ses"
+	amzDate := now.Format("20060102T150405Z")
+	dateStamp := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	payloadHash := sha256Hex(body)
+	canonicalHeaders := fmt.Sprintf("content-type:%s\nhost:%s\nx-amz-date:%s\n", req.Header.Get("Content-Type"), req.Host, amzDate)
+	signedHeaders := "content-type;host;x-amz-date"
+
+	canonicalRequest := fmt.Sprintf("%s\n%s\n%s\n%s\n%s\n%s",
+		req.Method, req.URL.Path, req.URL.RawQuery, canonicalHeaders, signedHeaders, payloadHash)
+
+	credentialScope := fmt.Sprintf("%s/%s/%s/aws4_request", dateStamp, s.Region, service)
+	stringToSign := fmt.Sprintf("AWS4-HMAC-SHA256\n%s\n%s\n%s",
+		amzDate, credentialScope, sha256Hex([]byte(canonicalRequest)))
+
+	signingKey := s.signingKey(dateStamp, service)
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.AccessKeyID, credentialScope, signedHeaders, signature))
+}
+
+// signingKey derives the SigV4 signing key for the given date and service.
+func (s *SESSender) signingKey(dateStamp, service string) []byte {
+	kDate := hmacSHA256([]byte("AWS4"+s.SecretAccessKey), dateStamp)
+	kRegion := hmacSHA256(kDate, s.Region)
+	kService := hmacSHA256(kRegion, service)
+	return hmacSHA256(kService, "aws4_request")
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of data keyed by key.
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// sha256Hex returns the hex-encoded SHA-256 digest of data.
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
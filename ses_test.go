@@ -0,0 +1,106 @@
+package goemail
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSESSenderRequestShapeSimpleContent(t *testing.T) {
+	var gotInput sesSendEmailInput
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			t.Error("request was not SigV4-signed")
+		}
+		if err := json.NewDecoder(r.Body).Decode(&gotInput); err != nil {
+			t.Fatalf("decoding request body: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	sender := &SESSender{Region: "us-east-1", AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret", baseURL: ts.URL}
+	e := NewWithSender(sender)
+	e.SetSender("from@example.com")
+	e.SetSenderName("Example Sender")
+	e.SetTo([]string{"to@example.com"})
+	e.SetSubject("Hello")
+	if err := e.SetContent(Content{
+		Type:          ContentHTML,
+		Content:       "<p>Hi there</p>",
+		AutoPlainText: true,
+	}); err != nil {
+		t.Fatalf("SetContent returned error: %v", err)
+	}
+
+	if err := e.Send(context.Background(), 1); err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+
+	if gotInput.FromEmailAddress != "Example Sender <from@example.com>" {
+		t.Errorf("FromEmailAddress = %q, want %q", gotInput.FromEmailAddress, "Example Sender <from@example.com>")
+	}
+	if len(gotInput.Destination.ToAddresses) != 1 || gotInput.Destination.ToAddresses[0] != "to@example.com" {
+		t.Errorf("ToAddresses = %v, want [to@example.com]", gotInput.Destination.ToAddresses)
+	}
+	if gotInput.Content.Raw != nil {
+		t.Fatal("expected Simple content when no attachments are set, got Raw")
+	}
+	if gotInput.Content.Simple == nil {
+		t.Fatal("expected Simple content to be set")
+	}
+	if gotInput.Content.Simple.Body.Html == nil || gotInput.Content.Simple.Body.Html.Data != "<p>Hi there</p>" {
+		t.Errorf("Simple.Body.Html = %+v, want Data %q", gotInput.Content.Simple.Body.Html, "<p>Hi there</p>")
+	}
+	if gotInput.Content.Simple.Body.Text == nil || gotInput.Content.Simple.Body.Text.Data == "" {
+		t.Error("Simple.Body.Text (plain-text alternative) was not set alongside Html")
+	}
+}
+
+func TestSESSenderRequestShapeRawContentWithAttachment(t *testing.T) {
+	var gotInput sesSendEmailInput
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&gotInput); err != nil {
+			t.Fatalf("decoding request body: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	sender := &SESSender{Region: "us-east-1", AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret", baseURL: ts.URL}
+	e := NewWithSender(sender)
+	e.SetSender("from@example.com")
+	e.SetTo([]string{"to@example.com"})
+	e.SetSubject("Hello")
+	if err := e.SetContent(Content{Type: ContentHTML, Content: "<p>Hi</p>"}); err != nil {
+		t.Fatalf("SetContent returned error: %v", err)
+	}
+	e.AttachReader("report.txt", strings.NewReader("report body"))
+
+	if err := e.Send(context.Background(), 1); err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+
+	if gotInput.Content.Simple != nil {
+		t.Fatal("expected Raw content when an attachment is set, got Simple")
+	}
+	if gotInput.Content.Raw == nil || gotInput.Content.Raw.Data == "" {
+		t.Fatal("expected Raw.Data to hold the base64-encoded MIME message")
+	}
+	raw, err := base64.StdEncoding.DecodeString(gotInput.Content.Raw.Data)
+	if err != nil {
+		t.Fatalf("Raw.Data is not valid base64: %v", err)
+	}
+	if want := base64.StdEncoding.EncodeToString([]byte("report body")); !strings.Contains(string(raw), want) {
+		t.Error("raw MIME message does not contain the attachment's (base64-encoded) content")
+	}
+	if !strings.Contains(string(raw), "report.txt") {
+		t.Error("raw MIME message does not reference the attachment's file name")
+	}
+}